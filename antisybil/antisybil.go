@@ -0,0 +1,45 @@
+// Package antisybil gates the faucet's withdraw endpoint behind a solved
+// CAPTCHA or a sufficiently established GitHub account, the standard
+// abuse-prevention pattern for public testnet faucets.
+package antisybil
+
+import (
+	"net/http"
+	"time"
+)
+
+// Config mirrors the `captcha:` and `github:` sections of configs.yaml.
+type Config struct {
+	Captcha CaptchaConfig `mapstructure:"captcha"`
+	GitHub  GitHubConfig  `mapstructure:"github"`
+}
+
+// CaptchaConfig configures the hCaptcha/Turnstile verification call.
+type CaptchaConfig struct {
+	// Provider is "hcaptcha" or "turnstile".
+	Provider string `mapstructure:"provider"`
+	Secret   string `mapstructure:"secret"`
+}
+
+// GitHubConfig configures the GitHub OAuth account-age/contributions check.
+type GitHubConfig struct {
+	ClientID          string `mapstructure:"client_id"`
+	MinAccountAgeDays int    `mapstructure:"min_account_age_days"`
+	MinPublicContribs int    `mapstructure:"min_public_contributions"`
+}
+
+// Verifier checks whether a withdraw request has proven it isn't an
+// automated sybil: either a solved CAPTCHA token or a GitHub account old and
+// active enough to meet the configured thresholds.
+type Verifier struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// NewVerifier builds a Verifier from cfg.
+func NewVerifier(cfg Config) *Verifier {
+	return &Verifier{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
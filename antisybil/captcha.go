@@ -0,0 +1,62 @@
+package antisybil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	hcaptchaVerifyURL  = "https://hcaptcha.com/siteverify"
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+type siteverifyResponse struct {
+	Success bool `json:"success"`
+}
+
+// verifyCaptcha posts token to the configured provider's siteverify endpoint
+// and reports whether it was accepted.
+func (v *Verifier) verifyCaptcha(ctx context.Context, token, remoteIP string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	var endpoint string
+	switch v.cfg.Captcha.Provider {
+	case "hcaptcha":
+		endpoint = hcaptchaVerifyURL
+	case "turnstile":
+		endpoint = turnstileVerifyURL
+	default:
+		return false, fmt.Errorf("antisybil: unknown captcha provider %q", v.cfg.Captcha.Provider)
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.cfg.Captcha.Secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("antisybil: failed to build captcha request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("antisybil: captcha verification request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result siteverifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("antisybil: failed to decode captcha response: %w", err)
+	}
+	return result.Success, nil
+}
@@ -0,0 +1,58 @@
+package antisybil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const githubUserURL = "https://api.github.com/user"
+
+type githubUser struct {
+	Login       string    `json:"login"`
+	CreatedAt   time.Time `json:"created_at"`
+	PublicRepos int       `json:"public_repos"`
+	PublicGists int       `json:"public_gists"`
+}
+
+// verifyGitHub looks up the account behind bearerToken and reports whether it
+// is older than MinAccountAgeDays and has more than MinPublicContribs public
+// repos/gists combined.
+func (v *Verifier) verifyGitHub(ctx context.Context, bearerToken string) (bool, error) {
+	if bearerToken == "" {
+		return false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubUserURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("antisybil: failed to build github request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+bearerToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("antisybil: github lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, nil
+	}
+
+	var user githubUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return false, fmt.Errorf("antisybil: failed to decode github response: %w", err)
+	}
+
+	age := time.Since(user.CreatedAt)
+	if age < time.Duration(v.cfg.GitHub.MinAccountAgeDays)*24*time.Hour {
+		return false, nil
+	}
+	if user.PublicRepos+user.PublicGists <= v.cfg.GitHub.MinPublicContribs {
+		return false, nil
+	}
+	return true, nil
+}
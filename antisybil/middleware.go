@@ -0,0 +1,53 @@
+package antisybil
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiResponse mirrors main.ApiResponse so this package doesn't need to
+// import the main package to shape its error body.
+type apiResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// Middleware returns a Gin handler that rejects requests unless they carry
+// either a solved CAPTCHA token (header "X-Captcha-Token") or a GitHub OAuth
+// bearer token (header "Authorization") for an account old and active enough
+// to pass the configured thresholds.
+func (v *Verifier) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		if captchaToken := c.GetHeader("X-Captcha-Token"); captchaToken != "" {
+			ok, err := v.verifyCaptcha(ctx, captchaToken, c.ClientIP())
+			if err == nil && ok {
+				c.Next()
+				return
+			}
+		}
+
+		if bearer := extractBearerToken(c.GetHeader("Authorization")); bearer != "" {
+			ok, err := v.verifyGitHub(ctx, bearer)
+			if err == nil && ok {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, apiResponse{
+			Success: false,
+			Message: "Please solve the CAPTCHA or sign in with a qualifying GitHub account before requesting funds.",
+		})
+	}
+}
+
+func extractBearerToken(header string) string {
+	const prefix = "Bearer "
+	if len(header) > len(prefix) && header[:len(prefix)] == prefix {
+		return header[len(prefix):]
+	}
+	return ""
+}
@@ -0,0 +1,24 @@
+package antisybil
+
+import "testing"
+
+func TestExtractBearerToken(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"well-formed bearer header", "Bearer abc123", "abc123"},
+		{"missing header", "", ""},
+		{"wrong scheme", "Basic abc123", ""},
+		{"bearer prefix with no token", "Bearer ", ""},
+		{"bearer prefix only, no trailing space", "Bearer", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := extractBearerToken(c.header); got != c.want {
+				t.Fatalf("extractBearerToken(%q) = %q, want %q", c.header, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+// Package dispenser serializes withdrawal sends behind a single goroutine
+// per signer. Two in-flight HTTP requests calling PendingNonceAt directly
+// can read the same pending nonce and collide; a Dispenser instead owns the
+// nonce counter itself and is the only thing that ever assigns a new nonce
+// for its signer; its optional TxMonitor only resends fee-bumped
+// replacements at nonces the Dispenser has already used.
+package dispenser
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/Faucet-ATM/Mantle_Faucet/txbuilder"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// TxSendRecorder observes how long a SendTransaction call took.
+type TxSendRecorder interface {
+	ObserveSendTransaction(networkName string, d time.Duration)
+}
+
+// QueueDepthRecorder reports how many jobs are currently buffered in a
+// dispenser's queue.
+type QueueDepthRecorder interface {
+	SetQueueDepth(networkName string, depth int)
+}
+
+// BatchSizeRecorder observes how many jobs were folded into one disperse call.
+type BatchSizeRecorder interface {
+	ObserveBatchSize(networkName string, size int)
+}
+
+// MetricsRecorder is everything a Dispenser (and the txbuilder.Builder it
+// wraps) needs to record metrics against. Defined here, rather than
+// importing a metrics package, so dispenser has no dependency on how or
+// whether the caller records metrics.
+type MetricsRecorder interface {
+	txbuilder.GasEstimateRecorder
+	TxSendRecorder
+	QueueDepthRecorder
+	BatchSizeRecorder
+}
+
+// Config mirrors the `dispenser:` section of configs.yaml.
+type Config struct {
+	// QueueDepth bounds the buffered job channel. Defaults to 256.
+	QueueDepth int `mapstructure:"queueDepth"`
+	// BatchThreshold is how many queued native-ETH jobs must be waiting
+	// before they're folded into a single Disperse contract call instead of
+	// being sent one transaction each. 0 (the default) disables batching.
+	BatchThreshold int `mapstructure:"batchThreshold"`
+	// DisperseContract is the address of a deployed Disperse-style contract
+	// exposing `disperseEther(address[] recipients, uint256[] values)`.
+	DisperseContract string `mapstructure:"disperseContract"`
+	// ResultTimeout bounds how long Send waits for queue capacity and for a
+	// result, combined, before giving up on a hung RPC call. Defaults to 30s.
+	ResultTimeout time.Duration `mapstructure:"resultTimeout"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.QueueDepth <= 0 {
+		c.QueueDepth = 256
+	}
+	if c.BatchThreshold < 0 {
+		c.BatchThreshold = 0
+	}
+	if c.ResultTimeout <= 0 {
+		c.ResultTimeout = 30 * time.Second
+	}
+	return c
+}
+
+// WithdrawJob is one withdrawal request waiting to be sent. Native ETH jobs
+// have Data == nil; ERC-20 jobs carry a pre-packed `transfer` call and To set
+// to the token contract address.
+type WithdrawJob struct {
+	To     common.Address
+	Value  *big.Int
+	Data   []byte
+	Result chan Result
+}
+
+// Result is delivered on WithdrawJob.Result once the job has been sent (or
+// failed to send).
+type Result struct {
+	TxHash common.Hash
+	Err    error
+}
+
+// Dispenser owns a single signer's nonce and serializes every transaction it
+// sends through one background goroutine.
+type Dispenser struct {
+	client     *ethclient.Client
+	builder    *txbuilder.Builder
+	privateKey *ecdsa.PrivateKey
+	from       common.Address
+	cfg        Config
+
+	disperser *disperseCaller
+	monitor   *txbuilder.TxMonitor // optional; watched for bump/resubmit if set
+
+	networkName string
+	recorder    MetricsRecorder
+
+	jobs chan *WithdrawJob
+	next uint64 // next nonce to assign; valid only inside run()
+
+	// nextSnapshot mirrors next so PendingNonceGap can read it safely from
+	// outside the run() goroutine.
+	nextSnapshot atomic.Uint64
+}
+
+// New starts a Dispenser's background goroutine and returns a handle to
+// submit jobs to it. ctx controls the goroutine's lifetime. monitor may be
+// nil; when set, every transaction the Dispenser sends is registered with it
+// for stall detection and bump/resubmit. networkName and recorder are
+// optional and only used to label/emit metrics; pass "" and nil to skip it.
+func New(ctx context.Context, client *ethclient.Client, privateKey *ecdsa.PrivateKey, from common.Address, txCfg txbuilder.Config, cfg Config, monitor *txbuilder.TxMonitor, networkName string, recorder MetricsRecorder) (*Dispenser, error) {
+	cfg = cfg.withDefaults()
+
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("dispenser: failed to fetch starting nonce: %w", err)
+	}
+
+	var disperser *disperseCaller
+	if cfg.BatchThreshold > 0 && cfg.DisperseContract != "" {
+		disperser, err = newDisperseCaller(cfg.DisperseContract)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d := &Dispenser{
+		client:      client,
+		builder:     txbuilder.NewBuilder(client, txCfg, networkName, recorder),
+		privateKey:  privateKey,
+		from:        from,
+		cfg:         cfg,
+		disperser:   disperser,
+		monitor:     monitor,
+		networkName: networkName,
+		recorder:    recorder,
+		jobs:        make(chan *WithdrawJob, cfg.QueueDepth),
+		next:        nonce,
+	}
+	d.nextSnapshot.Store(nonce)
+	go d.run(ctx)
+	return d, nil
+}
+
+// PendingNonceGap reports how far the nonce this Dispenser will assign next
+// is ahead of the chain's current pending nonce. It's persistently positive
+// when sent transactions have been dropped rather than mined.
+func (d *Dispenser) PendingNonceGap(ctx context.Context) (int64, error) {
+	pending, err := d.client.PendingNonceAt(ctx, d.from)
+	if err != nil {
+		return 0, fmt.Errorf("dispenser: failed to fetch pending nonce: %w", err)
+	}
+	return int64(d.nextSnapshot.Load()) - int64(pending), nil
+}
+
+// Submit enqueues job, blocking if the queue is full. Most callers should
+// use Send instead, which bounds that wait.
+func (d *Dispenser) Submit(job *WithdrawJob) {
+	d.jobs <- job
+}
+
+// Send enqueues job and waits for it to be processed, giving up once either
+// ctx is done or cfg.ResultTimeout elapses — whichever comes first — so a
+// caller (e.g. an HTTP handler) never blocks forever behind a full queue or
+// a hung RPC call. The deadline covers both waiting for queue capacity and
+// waiting for the result.
+func (d *Dispenser) Send(ctx context.Context, job *WithdrawJob) (Result, error) {
+	timer := time.NewTimer(d.cfg.ResultTimeout)
+	defer timer.Stop()
+
+	select {
+	case d.jobs <- job:
+	case <-timer.C:
+		return Result{}, fmt.Errorf("dispenser: timed out waiting for queue capacity")
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+
+	select {
+	case result := <-job.Result:
+		return result, nil
+	case <-timer.C:
+		return Result{}, fmt.Errorf("dispenser: timed out waiting for a result")
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+func (d *Dispenser) run(ctx context.Context) {
+	for {
+		var first *WithdrawJob
+		select {
+		case <-ctx.Done():
+			return
+		case first = <-d.jobs:
+		}
+
+		batch := []*WithdrawJob{first}
+		batch = d.drain(batch)
+		if d.recorder != nil {
+			d.recorder.SetQueueDepth(d.networkName, len(d.jobs))
+		}
+
+		if d.shouldBatch(batch) {
+			d.sendBatch(ctx, batch)
+			continue
+		}
+		for _, job := range batch {
+			d.sendSingle(ctx, job)
+		}
+	}
+}
+
+// drain opportunistically grabs any other jobs already queued, up to
+// BatchThreshold, without blocking — it never waits for more to arrive.
+func (d *Dispenser) drain(batch []*WithdrawJob) []*WithdrawJob {
+	limit := d.cfg.BatchThreshold
+	if limit <= 0 {
+		return batch
+	}
+	for len(batch) < limit {
+		select {
+		case job := <-d.jobs:
+			batch = append(batch, job)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (d *Dispenser) shouldBatch(batch []*WithdrawJob) bool {
+	if d.disperser == nil || len(batch) < d.cfg.BatchThreshold {
+		return false
+	}
+	for _, job := range batch {
+		if job.Data != nil {
+			return false // ERC-20 transfers can't be folded into disperseEther
+		}
+	}
+	return true
+}
+
+func (d *Dispenser) sendSingle(ctx context.Context, job *WithdrawJob) {
+	tx, err := d.builder.BuildWithNonce(ctx, d.from, job.To, job.Value, job.Data, d.next)
+	if err != nil {
+		job.Result <- Result{Err: fmt.Errorf("dispenser: failed to build transaction: %w", err)}
+		return
+	}
+	signed, err := d.signAndSend(ctx, tx)
+	if err != nil {
+		job.Result <- Result{Err: err}
+		return
+	}
+	d.next++
+	d.nextSnapshot.Store(d.next)
+	if d.monitor != nil {
+		d.monitor.Watch(ctx, signed, d.privateKey)
+	}
+	job.Result <- Result{TxHash: signed.Hash()}
+}
+
+func (d *Dispenser) sendBatch(ctx context.Context, batch []*WithdrawJob) {
+	if d.recorder != nil {
+		d.recorder.ObserveBatchSize(d.networkName, len(batch))
+	}
+
+	recipients := make([]common.Address, len(batch))
+	values := make([]*big.Int, len(batch))
+	total := big.NewInt(0)
+	for i, job := range batch {
+		recipients[i] = job.To
+		values[i] = job.Value
+		total = new(big.Int).Add(total, job.Value)
+	}
+
+	data, err := d.disperser.packDisperseEther(recipients, values)
+	if err != nil {
+		d.failAll(batch, fmt.Errorf("dispenser: failed to pack batch: %w", err))
+		return
+	}
+
+	tx, err := d.builder.BuildWithNonce(ctx, d.from, d.disperser.address, total, data, d.next)
+	if err != nil {
+		d.failAll(batch, fmt.Errorf("dispenser: failed to build batch transaction: %w", err))
+		return
+	}
+	signed, err := d.signAndSend(ctx, tx)
+	if err != nil {
+		d.failAll(batch, err)
+		return
+	}
+	d.next++
+	d.nextSnapshot.Store(d.next)
+	if d.monitor != nil {
+		d.monitor.Watch(ctx, signed, d.privateKey)
+	}
+	for _, job := range batch {
+		job.Result <- Result{TxHash: signed.Hash()}
+	}
+}
+
+func (d *Dispenser) signAndSend(ctx context.Context, dynamicTx *types.DynamicFeeTx) (*types.Transaction, error) {
+	tx := types.NewTx(dynamicTx)
+	signed, err := types.SignTx(tx, types.NewLondonSigner(dynamicTx.ChainID), d.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("dispenser: failed to sign transaction: %w", err)
+	}
+	start := time.Now()
+	err = d.client.SendTransaction(ctx, signed)
+	if d.recorder != nil {
+		d.recorder.ObserveSendTransaction(d.networkName, time.Since(start))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dispenser: failed to send transaction: %w", err)
+	}
+	return signed, nil
+}
+
+func (d *Dispenser) failAll(batch []*WithdrawJob, err error) {
+	for _, job := range batch {
+		job.Result <- Result{Err: err}
+	}
+}
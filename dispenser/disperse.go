@@ -0,0 +1,38 @@
+package dispenser
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// disperseABI is the single entry point the batching path needs from a
+// disperse.app-style contract: `disperseEther(address[], uint256[])`, which
+// forwards msg.value out to each recipient in one transaction.
+const disperseABI = `[
+	{"inputs":[{"name":"recipients","type":"address[]"},{"name":"values","type":"uint256[]"}],"name":"disperseEther","outputs":[],"stateMutability":"payable","type":"function"}
+]`
+
+// disperseCaller packs calls to a deployed Disperse contract.
+type disperseCaller struct {
+	address common.Address
+	abi     abi.ABI
+}
+
+func newDisperseCaller(contractAddr string) (*disperseCaller, error) {
+	parsed, err := abi.JSON(strings.NewReader(disperseABI))
+	if err != nil {
+		return nil, fmt.Errorf("dispenser: failed to parse disperse ABI: %w", err)
+	}
+	return &disperseCaller{
+		address: common.HexToAddress(contractAddr),
+		abi:     parsed,
+	}, nil
+}
+
+func (d *disperseCaller) packDisperseEther(recipients []common.Address, values []*big.Int) ([]byte, error) {
+	return d.abi.Pack("disperseEther", recipients, values)
+}
@@ -0,0 +1,84 @@
+package erc20
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// CapTracker enforces a token's per-request and daily dispensing caps. The
+// daily total resets every UTC day, kept in-memory the same way the faucet's
+// original withdrawal cooldown was before it grew a persistent backend.
+type CapTracker struct {
+	mu         sync.Mutex
+	dailyTotal map[string]*big.Int // symbol -> total dispensed since dayStart
+	dayStart   map[string]time.Time
+}
+
+// NewCapTracker builds an empty CapTracker.
+func NewCapTracker() *CapTracker {
+	return &CapTracker{
+		dailyTotal: make(map[string]*big.Int),
+		dayStart:   make(map[string]time.Time),
+	}
+}
+
+// Reserve checks amount (in base units) against token's per-request and daily
+// caps and, if both pass, adds it to today's running total for that symbol.
+func (t *CapTracker) Reserve(token Token, amount *big.Int) error {
+	if token.PerRequestCap != "" {
+		perRequestCap, ok := new(big.Int).SetString(token.PerRequestCap, 10)
+		if ok && amount.Cmp(perRequestCap) > 0 {
+			return fmt.Errorf("amount exceeds the %s per-request cap", token.Symbol)
+		}
+	}
+
+	if token.DailyCap == "" {
+		return nil
+	}
+	dailyCap, ok := new(big.Int).SetString(token.DailyCap, 10)
+	if !ok {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if start, ok := t.dayStart[token.Symbol]; !ok || !start.Equal(today) {
+		t.dayStart[token.Symbol] = today
+		t.dailyTotal[token.Symbol] = big.NewInt(0)
+	}
+
+	projected := new(big.Int).Add(t.dailyTotal[token.Symbol], amount)
+	if projected.Cmp(dailyCap) > 0 {
+		return fmt.Errorf("%s daily faucet cap reached, try again tomorrow", token.Symbol)
+	}
+	t.dailyTotal[token.Symbol] = projected
+	return nil
+}
+
+// Release gives back a prior Reserve of amount for token, e.g. because the
+// withdrawal that reserved it failed before it was actually sent. It
+// subtracts amount from the symbol's current daily total, floored at zero;
+// it does not check whether that total is still for the day on which the
+// reservation was made.
+func (t *CapTracker) Release(token Token, amount *big.Int) {
+	if token.DailyCap == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	total, ok := t.dailyTotal[token.Symbol]
+	if !ok {
+		return
+	}
+	total = new(big.Int).Sub(total, amount)
+	if total.Sign() < 0 {
+		total = big.NewInt(0)
+	}
+	t.dailyTotal[token.Symbol] = total
+}
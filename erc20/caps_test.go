@@ -0,0 +1,63 @@
+package erc20
+
+import (
+	"math/big"
+	"testing"
+)
+
+func testToken() Token {
+	return Token{Symbol: "TEST", PerRequestCap: "100", DailyCap: "250"}
+}
+
+func TestCapTrackerReserveEnforcesPerRequestCap(t *testing.T) {
+	tracker := NewCapTracker()
+	if err := tracker.Reserve(testToken(), big.NewInt(101)); err == nil {
+		t.Fatal("expected an error when the amount exceeds the per-request cap")
+	}
+}
+
+func TestCapTrackerReserveEnforcesDailyCap(t *testing.T) {
+	tracker := NewCapTracker()
+	token := testToken()
+
+	if err := tracker.Reserve(token, big.NewInt(100)); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if err := tracker.Reserve(token, big.NewInt(100)); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if err := tracker.Reserve(token, big.NewInt(100)); err == nil {
+		t.Fatal("expected an error once the daily cap is exceeded")
+	}
+}
+
+func TestCapTrackerReleaseFreesUpTheDailyCap(t *testing.T) {
+	tracker := NewCapTracker()
+	token := testToken()
+
+	if err := tracker.Reserve(token, big.NewInt(200)); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if err := tracker.Reserve(token, big.NewInt(100)); err == nil {
+		t.Fatal("expected an error, the reservation should still be in effect")
+	}
+
+	tracker.Release(token, big.NewInt(200))
+	if err := tracker.Reserve(token, big.NewInt(100)); err != nil {
+		t.Fatalf("Reserve after Release should succeed, got: %v", err)
+	}
+}
+
+func TestCapTrackerReleaseNeverGoesNegative(t *testing.T) {
+	tracker := NewCapTracker()
+	token := testToken()
+
+	if err := tracker.Reserve(token, big.NewInt(50)); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	tracker.Release(token, big.NewInt(500))
+
+	if err := tracker.Reserve(token, big.NewInt(250)); err != nil {
+		t.Fatalf("Reserve after over-releasing should still allow up to the full daily cap, got: %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+package erc20
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// LoadRegistry reads the `tokens:` section of configs.yaml into a Registry
+// keyed by upper-cased symbol.
+func LoadRegistry(v *viper.Viper) (Registry, error) {
+	var tokens []Token
+	if err := v.UnmarshalKey("tokens", &tokens); err != nil {
+		return nil, err
+	}
+	registry := make(Registry, len(tokens))
+	for _, t := range tokens {
+		registry[strings.ToUpper(t.Symbol)] = t
+	}
+	return registry, nil
+}
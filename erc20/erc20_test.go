@@ -0,0 +1,32 @@
+package erc20
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestTokenToBaseUnits(t *testing.T) {
+	cases := []struct {
+		name     string
+		decimals uint8
+		amount   float64
+		want     string
+	}{
+		{"6 decimals (USDC-like)", 6, 1.5, "1500000"},
+		{"18 decimals", 18, 1, "1000000000000000000"},
+		{"0 decimals", 0, 42, "42"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			token := Token{Symbol: "TEST", Decimals: c.decimals}
+			got := token.ToBaseUnits(big.NewFloat(c.amount))
+			want, ok := new(big.Int).SetString(c.want, 10)
+			if !ok {
+				t.Fatalf("bad test case: %q is not a valid big.Int", c.want)
+			}
+			if got.Cmp(want) != 0 {
+				t.Fatalf("ToBaseUnits(%v) = %s, want %s", c.amount, got, want)
+			}
+		})
+	}
+}
@@ -3,36 +3,59 @@ package main
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
-	"github.com/ethereum/go-ethereum"
+	"github.com/Faucet-ATM/Mantle_Faucet/antisybil"
+	"github.com/Faucet-ATM/Mantle_Faucet/dispenser"
+	"github.com/Faucet-ATM/Mantle_Faucet/erc20"
+	"github.com/Faucet-ATM/Mantle_Faucet/network"
+	"github.com/Faucet-ATM/Mantle_Faucet/observability"
+	"github.com/Faucet-ATM/Mantle_Faucet/ratelimit"
+	"github.com/Faucet-ATM/Mantle_Faucet/txbuilder"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"math/big"
 	"net/http"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 )
 
 var (
-	logger             *zap.Logger
-	cfg                *viper.Viper
-	integerDefault     int
-	privateKeyDefault  string
-	portDefault        string
-	accounts           = make(map[string]Account)
-	explorerUrlDefault string
+	logger            *zap.Logger
+	cfg               *viper.Viper
+	integerDefault    int
+	privateKeyDefault string
+	portDefault       string
+	limiter           ratelimit.Limiter
+	txCfg             txbuilder.Config
+	txMonitorsMu      sync.Mutex
+	txMonitors        = make(map[string]*txbuilder.TxMonitor) // keyed by network name
+	tokenRegistry     erc20.Registry
+	tokenCaps         = erc20.NewCapTracker()
+	networkRegistry   network.Registry
+	networkPool       *network.Pool
+	dispenserCfg      dispenser.Config
+	dispensersMu      sync.Mutex
+	dispensers        = make(map[string]*dispenser.Dispenser) // keyed by network name
+	metrics           *observability.Metrics
+	minBalanceWei     *big.Int
 )
 
 type RequestBody struct {
+	// Network must match a name configured under `networks:` in configs.yaml.
 	Network string `json:"network" banding:"required"`
 	Address string `json:"address" banding:"required"`
 	Amount  string `json:"amount" banding:"required"`
+	// Token is optional; when set to a symbol configured under `tokens:` the
+	// faucet dispenses that ERC-20 token instead of native ETH.
+	Token string `json:"token"`
 }
 type ApiResponse struct {
 	Success bool        `json:"success"`
@@ -40,12 +63,6 @@ type ApiResponse struct {
 	Data    interface{} `json:"data,omitempty"` // 使用 interface{} 类型允许这个字段保存任何类型的数据
 }
 
-// 记录账户领取的时间
-type Account struct {
-	Address          string    `json:"address"`
-	LastWithdrawTime time.Time `json:"last_withdraw_time"`
-}
-
 func main() {
 	// 初始化日志记录器
 	initLogger()
@@ -58,11 +75,122 @@ func main() {
 		logger.Error("Failed to initialize config", zap.Error(err))
 		os.Exit(1)
 	}
+	// 初始化限流器
+	rlCfg := ratelimit.Config{
+		Backend: cfg.GetString("rateLimit.backend"),
+		Window:  time.Duration(integerDefault) * time.Hour,
+	}
+	rlCfg.Redis.Addr = cfg.GetString("rateLimit.redis.addr")
+	rlCfg.Redis.Password = cfg.GetString("rateLimit.redis.password")
+	rlCfg.Redis.DB = cfg.GetInt("rateLimit.redis.db")
+	rlCfg.SQLite.Path = cfg.GetString("rateLimit.sqlite.path")
+	limiter, err = ratelimit.New(rlCfg)
+	if err != nil {
+		logger.Error("Failed to initialize rate limiter", zap.Error(err))
+		os.Exit(1)
+	}
+
+	// 初始化 EIP-1559 费用策略配置
+	txCfg = txbuilder.Config{
+		BaseFeeMultiplier: cfg.GetFloat64("txbuilder.baseFeeMultiplier"),
+		StallBlocks:       uint64(cfg.GetInt("txbuilder.stallBlocks")),
+		BumpPercent:       cfg.GetFloat64("txbuilder.bumpPercent"),
+	}
+
+	// 加载 ERC-20 代币配置
+	tokenRegistry, err = erc20.LoadRegistry(cfg)
+	if err != nil {
+		logger.Error("Failed to load token registry", zap.Error(err))
+		os.Exit(1)
+	}
+
+	// 加载网络白名单，替换掉直接拼接 "https://"+req.Network 的旧逻辑
+	networkRegistry, err = network.LoadRegistry(cfg)
+	if err != nil {
+		logger.Error("Failed to load network registry", zap.Error(err))
+		os.Exit(1)
+	}
+	networkPool = network.NewPool(networkRegistry)
+	defer networkPool.Close()
+
+	// 初始化按签名者串行发送的 dispenser 配置
+	dispenserCfg = dispenser.Config{
+		QueueDepth:       cfg.GetInt("dispenser.queueDepth"),
+		BatchThreshold:   cfg.GetInt("dispenser.batchThreshold"),
+		DisperseContract: cfg.GetString("dispenser.disperseContract"),
+		ResultTimeout:    cfg.GetDuration("dispenser.resultTimeout"),
+	}
+
+	// 初始化 Prometheus 指标，并按网络周期性上报水龙头钱包余额与待处理 nonce 差值
+	metrics = observability.NewMetrics()
+
+	minBalanceWei = big.NewInt(0)
+	if raw := cfg.GetString("observability.minBalanceWei"); raw != "" {
+		if parsed, ok := new(big.Int).SetString(raw, 10); ok {
+			minBalanceWei = parsed
+		}
+	}
+
+	pollInterval := time.Duration(cfg.GetInt("observability.pollIntervalSeconds")) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = 30 * time.Second
+	}
+	networkNames := make([]string, 0, len(networkRegistry))
+	for name := range networkRegistry {
+		networkNames = append(networkNames, name)
+	}
+	go observability.PollBalances(context.Background(), metrics, logger, networkNames, pollInterval, faucetBalance)
+	go pollNonceGaps(context.Background(), pollInterval)
+
+	// 初始化 CAPTCHA / GitHub OAuth 防女巫校验
+	antisybilVerifier := antisybil.NewVerifier(antisybil.Config{
+		Captcha: antisybil.CaptchaConfig{
+			Provider: cfg.GetString("captcha.provider"),
+			Secret:   cfg.GetString("captcha.secret"),
+		},
+		GitHub: antisybil.GitHubConfig{
+			ClientID:          cfg.GetString("github.client_id"),
+			MinAccountAgeDays: cfg.GetInt("github.min_account_age_days"),
+			MinPublicContribs: cfg.GetInt("github.min_public_contributions"),
+		},
+	})
+
+	// /readyz 对每个配置的网络都检查一次 RPC 是否可达、水龙头余额是否高于下限
+	var readinessChecks []observability.ReadinessCheck
+	for name := range networkRegistry {
+		name := name
+		readinessChecks = append(readinessChecks, observability.ReadinessCheck{
+			Name: name,
+			Check: func(ctx context.Context) error {
+				client, _, err := networkPool.Get(ctx, name)
+				if err != nil {
+					return err
+				}
+				if _, err := client.BlockNumber(ctx); err != nil {
+					return fmt.Errorf("rpc unreachable: %w", err)
+				}
+				balance, err := faucetBalance(ctx, name)
+				if err != nil {
+					return fmt.Errorf("failed to read faucet balance: %w", err)
+				}
+				if balance.Cmp(minBalanceWei) < 0 {
+					return fmt.Errorf("faucet balance %s below minimum %s", balance, minBalanceWei)
+				}
+				return nil
+			},
+		})
+	}
+
 	// 创建 Gin 引擎
 	r := gin.Default()
 
 	// 设置路由
-	r.POST("/mantle/request", handleWithdraw)
+	r.GET("/healthz", observability.Healthz)
+	r.GET("/readyz", observability.Readyz(readinessChecks...))
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	r.POST("/mantle/request", observability.RequestID(), antisybilVerifier.Middleware(), handleWithdraw)
+	r.GET("/mantle/tx/:hash", handleTxStatus)
 
 	r.Run(portDefault)
 }
@@ -77,6 +205,10 @@ func handleWithdraw(c *gin.Context) {
 		return
 	}
 
+	reqLogger := observability.Logger(c, logger)
+	outcome := "error"
+	defer func() { metrics.RecordRequest(req.Network, outcome) }()
+
 	Address := req.Address
 	if !common.IsHexAddress(Address) {
 		c.JSON(http.StatusBadRequest, ApiResponse{
@@ -86,45 +218,96 @@ func handleWithdraw(c *gin.Context) {
 		return
 	}
 
-	account_user, exists := accounts[Address]
-	if exists {
-		// 检查是否满足 24 小时的条件
-		duration := time.Duration(integerDefault) * time.Hour
-		if time.Since(account_user.LastWithdrawTime) < duration {
-			c.JSON(http.StatusForbidden, ApiResponse{
-				Success: false,
-				Message: "You can only withdraw once every 24 hours.",
-			})
-			return
-		}
+	// 冷却期限流，按钱包地址和来源 IP 分别计算并独立生效，防止换一个没用过的钱包
+	// 或者换个 IP 就能绕开冷却
+	rlKey := ratelimit.Key{Address: Address, IP: c.ClientIP()}
+	allowed, retryAfter, err := limiter.Allow(c.Request.Context(), rlKey)
+	if err != nil {
+		reqLogger.Error("Failed to check rate limit", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ApiResponse{
+			Success: false,
+			Message: "Failed to check rate limit",
+		})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, ApiResponse{
+			Success: false,
+			Message: fmt.Sprintf("You can only withdraw once every %d hours. Try again in %s.", integerDefault, retryAfter.Round(time.Second)),
+		})
+		return
 	}
 
-	// 金额转换 wei=>eth
 	amountFloat64, err := strconv.ParseFloat(req.Amount, 64)
 	if err != nil {
 		fmt.Println("Error converting string to float64:", err)
 		return
 	}
-	amount := big.NewFloat(amountFloat64)
-	amount = amount.Mul(amount, big.NewFloat(1e18))
-	intAmount := new(big.Int)
-	amount.Int(intAmount)
 
-	client, err := ethclient.DialContext(context.Background(), "https://"+req.Network)
+	// Token 为空表示原生代币，沿用 1e18 的 wei 换算；否则按 tokens 配置中的 decimals 换算
+	var token erc20.Token
+	isToken := req.Token != ""
+	if isToken {
+		var ok bool
+		token, ok = tokenRegistry.Lookup(req.Token)
+		if !ok {
+			c.JSON(http.StatusBadRequest, ApiResponse{
+				Success: false,
+				Message: fmt.Sprintf("Unsupported token %q.", req.Token),
+			})
+			return
+		}
+	}
+
+	var intAmount *big.Int
+	if isToken {
+		intAmount = token.ToBaseUnits(big.NewFloat(amountFloat64))
+	} else {
+		amount := big.NewFloat(amountFloat64)
+		amount = amount.Mul(amount, big.NewFloat(1e18))
+		intAmount = new(big.Int)
+		amount.Int(intAmount)
+	}
+
+	if isToken {
+		if err := tokenCaps.Reserve(token, intAmount); err != nil {
+			c.JSON(http.StatusForbidden, ApiResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+		// Give the reservation back unless the send actually succeeds, so a
+		// failed request doesn't permanently burn into the daily cap.
+		defer func() {
+			if outcome != "success" {
+				tokenCaps.Release(token, intAmount)
+			}
+		}()
+	}
+
+	client, net, err := networkPool.Get(c.Request.Context(), req.Network)
 	if err != nil {
-		logger.Error("Failed to connect to Mantle client", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ApiResponse{
+		c.JSON(http.StatusBadRequest, ApiResponse{
 			Success: false,
-			Message: "Failed to connect to Mantle client",
+			Message: err.Error(),
 		})
 		return
 	}
-	defer client.Close()
 
-	privateKey, err := crypto.HexToECDSA(privateKeyDefault)
-	if err != nil {
-		logger.Error("Failed to decode private key", zap.Error(err))
+	if !isToken {
+		if capWei, ok := net.PerRequestCapWei(); ok && intAmount.Cmp(capWei) > 0 {
+			c.JSON(http.StatusForbidden, ApiResponse{
+				Success: false,
+				Message: fmt.Sprintf("Requested amount exceeds the %s per-request cap.", req.Network),
+			})
+			return
+		}
+	}
 
+	privateKey, err := resolveFaucetPrivateKey(net)
+	if err != nil {
+		reqLogger.Error("Failed to decode private key", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ApiResponse{
 			Success: false,
 			Message: "Failed to decode private key",
@@ -132,11 +315,9 @@ func handleWithdraw(c *gin.Context) {
 		return
 	}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-
+	publicKeyECDSA, ok := privateKey.Public().(*ecdsa.PublicKey)
 	if !ok {
-		logger.Error("Failed to decode private key", zap.Error(err))
+		reqLogger.Error("Failed to decode private key")
 		c.JSON(http.StatusInternalServerError, ApiResponse{
 			Success: false,
 			Message: "Failed to decode private key",
@@ -145,115 +326,247 @@ func handleWithdraw(c *gin.Context) {
 	}
 
 	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
+	toAddress := common.HexToAddress(Address)
 
-	account := common.HexToAddress(fromAddress.String()) // vitalik
-	balance, _ := client.BalanceAt(context.Background(), account, nil)
-	if balance.Cmp(intAmount) == -1 {
-		logger.Error("Insufficient balance", zap.Error(err))
-		c.JSON(http.StatusBadRequest, ApiResponse{
-			Success: false,
-			Message: "Insufficient balance",
-		})
-		return
+	// 原生代币走 DynamicFeeTx 的 Value 路径；ERC-20 代币走合约调用路径，
+	// to 改为代币合约地址、Value 为 0、Data 为打包好的 transfer 调用
+	txTo := toAddress
+	txValue := intAmount
+	var txData []byte
+	var tokenContract *erc20.Contract
+
+	if isToken {
+		tokenContract, err = erc20.NewContract(client, token)
+		if err != nil {
+			reqLogger.Error("Failed to bind token contract", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ApiResponse{
+				Success: false,
+				Message: "Failed to bind token contract",
+			})
+			return
+		}
+		tokenBalance, err := tokenContract.BalanceOf(nil, fromAddress)
+		if err != nil {
+			reqLogger.Error("Failed to read token balance", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ApiResponse{
+				Success: false,
+				Message: "Failed to read token balance",
+			})
+			return
+		}
+		if tokenBalance.Cmp(intAmount) == -1 {
+			c.JSON(http.StatusBadRequest, ApiResponse{
+				Success: false,
+				Message: "Insufficient balance",
+			})
+			return
+		}
+
+		txData, err = tokenContract.PackTransfer(toAddress, intAmount)
+		if err != nil {
+			reqLogger.Error("Failed to pack token transfer", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ApiResponse{
+				Success: false,
+				Message: "Failed to pack token transfer",
+			})
+			return
+		}
+		txTo = tokenContract.Address()
+		txValue = big.NewInt(0)
+	} else {
+		balance, _ := client.BalanceAt(context.Background(), fromAddress, nil)
+		if balance.Cmp(intAmount) == -1 {
+			reqLogger.Error("Insufficient balance")
+			c.JSON(http.StatusBadRequest, ApiResponse{
+				Success: false,
+				Message: "Insufficient balance",
+			})
+			return
+		}
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	// handleWithdraw 只负责生产任务；真正的签名、nonce 分配和发送都交给这个网络
+	// 唯一的 Dispenser 串行处理，避免并发请求读到同一个 pending nonce 而冲突
+	d, err := getOrCreateDispenser(req.Network, client, privateKey, fromAddress)
 	if err != nil {
-		logger.Error("Failed to get nonce", zap.Error(err))
+		reqLogger.Error("Failed to start dispenser", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ApiResponse{
 			Success: false,
-			Message: "Failed to get nonce",
+			Message: "Failed to start dispenser",
 		})
 		return
 	}
 
-	gasFeeCap, err := client.SuggestGasPrice(context.Background())
+	job := &dispenser.WithdrawJob{
+		To:     txTo,
+		Value:  txValue,
+		Data:   txData,
+		Result: make(chan dispenser.Result, 1),
+	}
+	result, err := d.Send(c.Request.Context(), job)
 	if err != nil {
-		logger.Error("Failed to get gas price", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ApiResponse{
+		status := http.StatusGatewayTimeout
+		if errors.Is(err, context.Canceled) {
+			status = http.StatusServiceUnavailable
+		}
+		reqLogger.Error("Failed to send withdrawal", zap.Error(err))
+		c.JSON(status, ApiResponse{
 			Success: false,
-			Message: "Failed to get gas price",
+			Message: "Timed out waiting for the transaction to send; it may still complete in the background.",
 		})
 		return
 	}
-
-	gasTipCap, err := client.SuggestGasTipCap(context.Background())
-	if err != nil {
-		logger.Error("Failed to get gas tip cap", zap.Error(err))
+	if result.Err != nil {
+		reqLogger.Error(result.Err.Error())
 		c.JSON(http.StatusInternalServerError, ApiResponse{
 			Success: false,
-			Message: "Failed to get gas tip cap",
+			Message: "Deal failed",
 		})
 		return
+	}
 
+	outcome = "success"
+	resp := gin.H{
+		"success":      true,
+		"tx_id":        result.TxHash.Hex(),
+		"explorer_url": net.ExplorerURL + result.TxHash.Hex(),
+	}
+	if isToken {
+		resp["token"] = token.Symbol
+		resp["decimals"] = token.Decimals
 	}
-	var data []byte
+	c.JSON(http.StatusOK, resp)
+}
 
-	chainID, err := client.NetworkID(context.Background())
-	if err != nil {
-		logger.Error("Failed to get network ID", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ApiResponse{
-			Success: false,
-			Message: "Failed to get network ID",
-		})
-		return
+// getOrCreateDispenser returns the network's Dispenser, creating it (and its
+// TxMonitor) on first use. Each network gets exactly one signer goroutine.
+func getOrCreateDispenser(networkName string, client *ethclient.Client, privateKey *ecdsa.PrivateKey, fromAddress common.Address) (*dispenser.Dispenser, error) {
+	dispensersMu.Lock()
+	defer dispensersMu.Unlock()
+
+	if d, ok := dispensers[networkName]; ok {
+		return d, nil
 	}
 
-	toAddress := common.HexToAddress(Address)
-	//gasLimit := uint64(21000)
-
-	// 动态估算 gasLimit
-	msg := ethereum.CallMsg{
-		From:      fromAddress,
-		To:        &toAddress,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		Value:     intAmount,
-		Data:      data,
-	}
-	gasLimit, err := client.EstimateGas(context.Background(), msg)
+	txMonitorsMu.Lock()
+	monitor, ok := txMonitors[networkName]
+	if !ok {
+		monitor = txbuilder.NewTxMonitor(client, txCfg)
+		txMonitors[networkName] = monitor
+	}
+	txMonitorsMu.Unlock()
+
+	d, err := dispenser.New(context.Background(), client, privateKey, fromAddress, txCfg, dispenserCfg, monitor, networkName, metrics)
 	if err != nil {
-		logger.Error("Failed to estimate gas", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, ApiResponse{
-			Success: false,
-			Message: "Failed to estimate gas",
-		})
-		return
+		return nil, err
 	}
+	dispensers[networkName] = d
+	return d, nil
+}
 
-	// 构造交易
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   chainID,
-		Nonce:     nonce,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		Gas:       gasLimit,
-		To:        &toAddress,
-		Value:     intAmount,
-		Data:      data,
-	})
+// resolveFaucetPrivateKey decodes the faucet's signing key for net, falling
+// back to the default key when the network didn't configure its own.
+func resolveFaucetPrivateKey(net network.Network) (*ecdsa.PrivateKey, error) {
+	key := net.FaucetPrivateKey
+	if key == "" {
+		key = privateKeyDefault
+	}
+	return crypto.HexToECDSA(key)
+}
 
-	// 发送交易
-	signedTx, err := types.SignTx(tx, types.NewLondonSigner(chainID), privateKey)
-	err = client.SendTransaction(context.Background(), signedTx)
+// resolveFaucetAddress derives the faucet wallet address for net from its
+// signing key.
+func resolveFaucetAddress(net network.Network) (common.Address, error) {
+	privateKey, err := resolveFaucetPrivateKey(net)
 	if err != nil {
-		logger.Error(err.Error())
-		c.JSON(http.StatusInternalServerError, ApiResponse{
+		return common.Address{}, err
+	}
+	publicKeyECDSA, ok := privateKey.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return common.Address{}, fmt.Errorf("failed to derive public key from faucet private key")
+	}
+	return crypto.PubkeyToAddress(*publicKeyECDSA), nil
+}
+
+// faucetBalance reports the faucet wallet's current native balance on
+// networkName. It backs both the /readyz balance check and the periodic
+// faucet-wallet-balance gauge.
+func faucetBalance(ctx context.Context, networkName string) (*big.Int, error) {
+	client, net, err := networkPool.Get(ctx, networkName)
+	if err != nil {
+		return nil, err
+	}
+	address, err := resolveFaucetAddress(net)
+	if err != nil {
+		return nil, err
+	}
+	return client.BalanceAt(ctx, address, nil)
+}
+
+// pollNonceGaps periodically reports every active dispenser's pending-nonce
+// gap so a stuck signer shows up in Prometheus before anyone has to look up
+// a stalled transaction by hash to notice it.
+func pollNonceGaps(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			dispensersMu.Lock()
+			snapshot := make(map[string]*dispenser.Dispenser, len(dispensers))
+			for name, d := range dispensers {
+				snapshot[name] = d
+			}
+			dispensersMu.Unlock()
+
+			for name, d := range snapshot {
+				gap, err := d.PendingNonceGap(ctx)
+				if err != nil {
+					logger.Warn("Failed to poll pending-nonce gap", zap.String("network", name), zap.Error(err))
+					continue
+				}
+				metrics.SetPendingNonceGap(name, float64(gap))
+			}
+		}
+	}
+}
+
+// handleTxStatus reports the last known status of a withdrawal transaction
+// previously submitted through /mantle/request.
+func handleTxStatus(c *gin.Context) {
+	hash := c.Param("hash")
+	if len(hash) != 66 || hash[:2] != "0x" {
+		c.JSON(http.StatusBadRequest, ApiResponse{
 			Success: false,
-			Message: "Deal failed",
+			Message: "Please provide a valid transaction hash.",
 		})
 		return
 	}
 
-	accounts[Address] = Account{
-		Address:          Address,
-		LastWithdrawTime: time.Now(),
+	txHash := common.HexToHash(hash)
+	var info *txbuilder.TxInfo
+	txMonitorsMu.Lock()
+	for _, m := range txMonitors {
+		if i, ok := m.Status(txHash); ok {
+			info = i
+			break
+		}
+	}
+	txMonitorsMu.Unlock()
+	if info == nil {
+		c.JSON(http.StatusNotFound, ApiResponse{
+			Success: false,
+			Message: "Transaction not found",
+		})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success":      true,
-		"tx_id":        signedTx.Hash().Hex(),
-		"explorer_url": explorerUrlDefault + signedTx.Hash().Hex(),
+	c.JSON(http.StatusOK, ApiResponse{
+		Success: true,
+		Message: string(info.Status),
+		Data:    info,
 	})
 }
 
@@ -283,6 +596,5 @@ func initConfig() (*viper.Viper, error) {
 	integerDefault = v.GetInt("interval")
 	privateKeyDefault = v.GetString("privateKey")
 	portDefault = fmt.Sprintf(":%d", v.GetInt("port"))
-	explorerUrlDefault = v.GetString("sepolia.explorerUrl")
 	return v, nil
 }
@@ -0,0 +1,77 @@
+// Package network replaces the faucet's old `"https://"+req.Network` dial
+// with an explicit allowlist: every network the faucet will talk to must be
+// declared in configs.yaml, so a client can no longer point the faucet at an
+// arbitrary host by setting `network` in the request body.
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/viper"
+)
+
+// Network mirrors one entry of the `networks:` section of configs.yaml.
+type Network struct {
+	Name             string `mapstructure:"name"`
+	RPCURL           string `mapstructure:"rpcUrl"`
+	ChainID          int64  `mapstructure:"chainId"`
+	ExplorerURL      string `mapstructure:"explorerUrl"`
+	FaucetPrivateKey string `mapstructure:"faucetPrivateKey"`
+	PerRequestCapETH string `mapstructure:"perRequestCapEth"`
+}
+
+// Registry is the allowlist of networks the faucet is configured to serve,
+// keyed by name (e.g. "mantle-sepolia", "mantle-mainnet", "sepolia").
+type Registry map[string]Network
+
+// LoadRegistry reads the `networks:` section of configs.yaml.
+func LoadRegistry(v *viper.Viper) (Registry, error) {
+	var networks []Network
+	if err := v.UnmarshalKey("networks", &networks); err != nil {
+		return nil, err
+	}
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("network: no networks configured under `networks:`")
+	}
+	registry := make(Registry, len(networks))
+	for _, n := range networks {
+		if n.PerRequestCapETH != "" {
+			if _, ok := n.PerRequestCapWei(); !ok {
+				return nil, fmt.Errorf("network: %q has an invalid perRequestCapEth %q", n.Name, n.PerRequestCapETH)
+			}
+		}
+		registry[n.Name] = n
+	}
+	return registry, nil
+}
+
+// Resolve looks up name in the allowlist, rejecting anything not explicitly
+// configured instead of dialing it directly.
+func (r Registry) Resolve(name string) (Network, error) {
+	n, ok := r[name]
+	if !ok {
+		return Network{}, fmt.Errorf("network: %q is not a configured network", name)
+	}
+	return n, nil
+}
+
+// PerRequestCapWei parses PerRequestCapETH — a human-readable ETH amount
+// such as "0.5", the same unit a withdrawal amount arrives in — and scales
+// it to wei the same way RequestBody.Amount is converted. ok is false when
+// the network didn't configure a cap, or the value isn't a valid number;
+// LoadRegistry already rejects the latter at startup, so callers past that
+// point only see ok=false for a genuinely uncapped network.
+func (n Network) PerRequestCapWei() (*big.Int, bool) {
+	if n.PerRequestCapETH == "" {
+		return nil, false
+	}
+	eth, ok := new(big.Float).SetString(n.PerRequestCapETH)
+	if !ok {
+		return nil, false
+	}
+	scaled := new(big.Float).Mul(eth, big.NewFloat(1e18))
+	wei := new(big.Int)
+	scaled.Int(wei)
+	return wei, true
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRegistryResolve(t *testing.T) {
+	registry := Registry{
+		"mantle-sepolia": Network{Name: "mantle-sepolia", RPCURL: "https://rpc.example/sepolia"},
+	}
+
+	if _, err := registry.Resolve("mantle-sepolia"); err != nil {
+		t.Fatalf("Resolve(configured network): %v", err)
+	}
+
+	if _, err := registry.Resolve("evil.example.com"); err == nil {
+		t.Fatal("Resolve should reject any name not explicitly configured")
+	}
+}
+
+func TestPerRequestCapWei(t *testing.T) {
+	cases := []struct {
+		name    string
+		cap     string
+		wantOK  bool
+		wantWei int64
+	}{
+		{"whole ETH cap", "1", true, 1000000000000000000},
+		{"fractional ETH cap", "0.5", true, 500000000000000000},
+		{"unset cap", "", false, 0},
+		{"invalid cap", "not-a-number", false, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			n := Network{PerRequestCapETH: c.cap}
+			got, ok := n.PerRequestCapWei()
+			if ok != c.wantOK {
+				t.Fatalf("PerRequestCapWei() ok = %v, want %v", ok, c.wantOK)
+			}
+			if ok && got.Int64() != c.wantWei {
+				t.Fatalf("PerRequestCapWei() = %s, want %d", got, c.wantWei)
+			}
+		})
+	}
+}
+
+func TestLoadRegistryRejectsInvalidPerRequestCap(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	config := fmt.Sprintf(`
+networks:
+  - name: mantle-sepolia
+    rpcUrl: https://rpc.example/sepolia
+    perRequestCapEth: %q
+`, "not-a-number")
+	if err := v.ReadConfig(strings.NewReader(config)); err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+
+	if _, err := LoadRegistry(v); err == nil {
+		t.Fatal("LoadRegistry should reject a network with an unparseable perRequestCapEth instead of silently treating it as uncapped")
+	}
+}
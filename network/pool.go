@@ -0,0 +1,78 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Pool keeps one long-lived *ethclient.Client per network, dialed lazily on
+// first use, instead of dialing a fresh connection for every withdraw
+// request.
+type Pool struct {
+	registry Registry
+
+	mu      sync.Mutex
+	clients map[string]*ethclient.Client
+}
+
+// NewPool wraps registry with an empty connection pool.
+func NewPool(registry Registry) *Pool {
+	return &Pool{
+		registry: registry,
+		clients:  make(map[string]*ethclient.Client),
+	}
+}
+
+// Get returns the pooled *ethclient.Client for name, dialing and caching it
+// on first use. On first dial, if the network configured a chainId, Get
+// verifies the RPC actually reports that chain id before caching the client,
+// so a misconfigured or compromised RPC endpoint can't silently sign
+// transactions for the wrong chain. The returned client is shared and must
+// not be closed by the caller; call Pool.Close when the faucet shuts down
+// instead.
+func (p *Pool) Get(ctx context.Context, name string) (*ethclient.Client, Network, error) {
+	net, err := p.registry.Resolve(name)
+	if err != nil {
+		return nil, Network{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[name]; ok {
+		return client, net, nil
+	}
+
+	client, err := ethclient.DialContext(ctx, net.RPCURL)
+	if err != nil {
+		return nil, Network{}, fmt.Errorf("network: failed to dial %q: %w", name, err)
+	}
+
+	if net.ChainID != 0 {
+		gotChainID, err := client.NetworkID(ctx)
+		if err != nil {
+			client.Close()
+			return nil, Network{}, fmt.Errorf("network: failed to fetch chain id for %q: %w", name, err)
+		}
+		if gotChainID.Cmp(big.NewInt(net.ChainID)) != 0 {
+			client.Close()
+			return nil, Network{}, fmt.Errorf("network: %q reports chain id %s, configured chainId is %d", name, gotChainID, net.ChainID)
+		}
+	}
+
+	p.clients[name] = client
+	return client, net, nil
+}
+
+// Close closes every pooled client. Intended for graceful shutdown.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, client := range p.clients {
+		client.Close()
+	}
+}
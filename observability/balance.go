@@ -0,0 +1,42 @@
+package observability
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BalanceSource returns the faucet wallet's current native balance on
+// networkName.
+type BalanceSource func(ctx context.Context, networkName string) (*big.Int, error)
+
+// PollBalances calls source for every name in networks every interval and
+// records the result on m.FaucetBalance, until ctx is cancelled. Run it in
+// its own goroutine for the lifetime of the process.
+func PollBalances(ctx context.Context, m *Metrics, logger *zap.Logger, networks []string, interval time.Duration, source BalanceSource) {
+	poll := func() {
+		for _, name := range networks {
+			balance, err := source(ctx, name)
+			if err != nil {
+				logger.Warn("observability: failed to poll faucet balance", zap.String("network", name), zap.Error(err))
+				continue
+			}
+			weiAsFloat, _ := new(big.Float).SetInt(balance).Float64()
+			m.SetBalance(name, weiAsFloat)
+		}
+	}
+
+	poll()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
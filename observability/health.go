@@ -0,0 +1,44 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadinessCheck is one condition /readyz verifies, e.g. "RPC for network X
+// is reachable" or "the faucet wallet on network X can still afford a
+// payout". Check returns a descriptive error when the condition fails.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Healthz reports only that the process itself is up; it never touches an
+// RPC, so a green /healthz just means the server is running.
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz runs every check on each request and reports 200 only if all of
+// them pass, so a load balancer can hold traffic back from an instance
+// whose RPCs are unreachable or whose faucet wallet has run dry.
+func Readyz(checks ...ReadinessCheck) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		failures := make(map[string]string)
+		for _, rc := range checks {
+			if err := rc.Check(c.Request.Context()); err != nil {
+				failures[rc.Name] = err.Error()
+			}
+		}
+		if len(failures) > 0 {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status":   "not ready",
+				"failures": failures,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready"})
+	}
+}
@@ -0,0 +1,97 @@
+// Package observability wires Prometheus metrics, a request-id middleware,
+// and the /healthz and /readyz endpoints into the faucet's Gin engine.
+package observability
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics holds every Prometheus collector the faucet reports.
+type Metrics struct {
+	RequestsTotal           *prometheus.CounterVec
+	EstimateGasDuration     *prometheus.HistogramVec
+	SendTransactionDuration *prometheus.HistogramVec
+	FaucetBalance           *prometheus.GaugeVec
+	PendingNonceGap         *prometheus.GaugeVec
+	DispenserQueueDepth     *prometheus.GaugeVec
+	DispenserBatchSize      *prometheus.HistogramVec
+}
+
+// NewMetrics registers every faucet collector against the default
+// registerer and returns a handle to record against them.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		RequestsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "mantle_faucet_requests_total",
+			Help: "Withdraw requests handled, by network and outcome.",
+		}, []string{"network", "outcome"}),
+		EstimateGasDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mantle_faucet_estimate_gas_duration_seconds",
+			Help:    "Latency of EstimateGas calls, by network.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"network"}),
+		SendTransactionDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mantle_faucet_send_transaction_duration_seconds",
+			Help:    "Latency of SendTransaction calls, by network.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"network"}),
+		FaucetBalance: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mantle_faucet_wallet_balance_wei",
+			Help: "Faucet wallet native balance, by network.",
+		}, []string{"network"}),
+		PendingNonceGap: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mantle_faucet_pending_nonce_gap",
+			Help: "How far the dispenser's next nonce is ahead of the chain's pending nonce, by network.",
+		}, []string{"network"}),
+		DispenserQueueDepth: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mantle_faucet_dispenser_queue_depth",
+			Help: "Withdraw jobs currently buffered in a dispenser's queue, by network.",
+		}, []string{"network"}),
+		DispenserBatchSize: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mantle_faucet_dispenser_batch_size",
+			Help:    "Number of jobs folded into a single disperse call, by network.",
+			Buckets: prometheus.LinearBuckets(1, 1, 10),
+		}, []string{"network"}),
+	}
+}
+
+// RecordRequest increments RequestsTotal for networkName/outcome.
+func (m *Metrics) RecordRequest(networkName, outcome string) {
+	m.RequestsTotal.WithLabelValues(networkName, outcome).Inc()
+}
+
+// ObserveEstimateGas records how long an EstimateGas call took.
+func (m *Metrics) ObserveEstimateGas(networkName string, d time.Duration) {
+	m.EstimateGasDuration.WithLabelValues(networkName).Observe(d.Seconds())
+}
+
+// ObserveSendTransaction records how long a SendTransaction call took.
+func (m *Metrics) ObserveSendTransaction(networkName string, d time.Duration) {
+	m.SendTransactionDuration.WithLabelValues(networkName).Observe(d.Seconds())
+}
+
+// SetBalance records the faucet wallet's current native balance for networkName.
+func (m *Metrics) SetBalance(networkName string, weiAsFloat float64) {
+	m.FaucetBalance.WithLabelValues(networkName).Set(weiAsFloat)
+}
+
+// SetPendingNonceGap records how far networkName's dispenser nonce is ahead
+// of the chain's pending nonce.
+func (m *Metrics) SetPendingNonceGap(networkName string, gap float64) {
+	m.PendingNonceGap.WithLabelValues(networkName).Set(gap)
+}
+
+// SetQueueDepth records how many jobs are currently buffered in a
+// dispenser's queue for networkName.
+func (m *Metrics) SetQueueDepth(networkName string, depth int) {
+	m.DispenserQueueDepth.WithLabelValues(networkName).Set(float64(depth))
+}
+
+// ObserveBatchSize records how many jobs were folded into one disperse call
+// for networkName.
+func (m *Metrics) ObserveBatchSize(networkName string, size int) {
+	m.DispenserBatchSize.WithLabelValues(networkName).Observe(float64(size))
+}
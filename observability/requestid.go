@@ -0,0 +1,46 @@
+package observability
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	requestIDHeader = "X-Request-Id"
+	requestIDKey    = "requestID"
+)
+
+// RequestID assigns every request a short id, reusing the caller's
+// X-Request-Id header when present, stores it on the Gin context and echoes
+// it back in the response header. Pair it with Logger so every log line a
+// handler writes for one withdrawal can be correlated by that id.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Writer.Header().Set(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+// Logger returns base with the request id stored on c by RequestID attached
+// as a field, or base unchanged if the middleware wasn't installed.
+func Logger(c *gin.Context, base *zap.Logger) *zap.Logger {
+	id, ok := c.Get(requestIDKey)
+	if !ok {
+		return base
+	}
+	return base.With(zap.String("request_id", id.(string)))
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
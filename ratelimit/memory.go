@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter is the in-process fallback backend. Like the old `accounts`
+// map it keeps cooldowns in process memory: fine for a single replica / local
+// dev, but the window resets on restart.
+type MemoryLimiter struct {
+	mu     sync.Mutex
+	window time.Duration
+	last   map[string]time.Time
+}
+
+// NewMemoryLimiter builds a Limiter backed by a plain map guarded by a mutex.
+func NewMemoryLimiter(window time.Duration) *MemoryLimiter {
+	return &MemoryLimiter{
+		window: window,
+		last:   make(map[string]time.Time),
+	}
+}
+
+func (m *MemoryLimiter) Allow(_ context.Context, key Key) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if retryAfter, limited := m.limited(key.addressKey(), now); limited {
+		return false, retryAfter, nil
+	}
+	if retryAfter, limited := m.limited(key.ipKey(), now); limited {
+		return false, retryAfter, nil
+	}
+	m.last[key.addressKey()] = now
+	m.last[key.ipKey()] = now
+	return true, 0, nil
+}
+
+// limited reports whether bucket k is still inside its cooldown window as of
+// now, and if so how much longer the caller must wait.
+func (m *MemoryLimiter) limited(k string, now time.Time) (time.Duration, bool) {
+	last, ok := m.last[k]
+	if !ok {
+		return 0, false
+	}
+	if elapsed := now.Sub(last); elapsed < m.window {
+		return m.window - elapsed, true
+	}
+	return 0, false
+}
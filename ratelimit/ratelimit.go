@@ -0,0 +1,62 @@
+// Package ratelimit provides pluggable rate limiting for the faucet's
+// withdraw endpoint. Unlike the old in-process accounts map, the Redis and
+// SQLite backends survive a process restart, and the Redis backend can be
+// shared across replicas; the in-memory backend does neither.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Key identifies a withdraw request's two rate-limit buckets: one for the
+// requested wallet address and one for the source IP. Allow must check (and
+// update) both independently — keying on the pair as a single bucket would
+// let a new wallet from the same IP, or the same wallet from a new IP,
+// reset the cooldown just by changing the field that isn't tracked.
+type Key struct {
+	Address string
+	IP      string
+}
+
+// addressKey and ipKey namespace the two independent buckets Allow checks.
+func (k Key) addressKey() string { return "addr:" + k.Address }
+func (k Key) ipKey() string      { return "ip:" + k.IP }
+
+// Limiter decides whether a withdraw request should be allowed through a
+// fixed cooldown window keyed on Key. Implementations must be safe for
+// concurrent use.
+type Limiter interface {
+	// Allow reports whether a request for key is permitted right now. When it
+	// is not, retryAfter is the duration the caller should wait before trying
+	// again.
+	Allow(ctx context.Context, key Key) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// Config mirrors the `rateLimit:` section of configs.yaml.
+type Config struct {
+	// Backend selects the storage: "memory", "redis", or "sqlite".
+	Backend string `mapstructure:"backend"`
+	// Window is the cooldown duration, e.g. 24h.
+	Window time.Duration `mapstructure:"window"`
+	Redis  RedisConfig   `mapstructure:"redis"`
+	SQLite SQLiteConfig  `mapstructure:"sqlite"`
+}
+
+// New builds the Limiter described by cfg.
+func New(cfg Config) (Limiter, error) {
+	if cfg.Window <= 0 {
+		cfg.Window = 24 * time.Hour
+	}
+	switch cfg.Backend {
+	case "", "memory":
+		return NewMemoryLimiter(cfg.Window), nil
+	case "redis":
+		return NewRedisLimiter(cfg.Redis, cfg.Window)
+	case "sqlite":
+		return NewSQLiteLimiter(cfg.SQLite, cfg.Window)
+	default:
+		return nil, fmt.Errorf("ratelimit: unknown backend %q", cfg.Backend)
+	}
+}
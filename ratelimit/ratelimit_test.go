@@ -0,0 +1,152 @@
+package ratelimit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// backendCases runs the same independent-bucket behavior against every
+// backend, using miniredis to exercise the Redis backend without a real
+// Redis instance.
+func backendCases(t *testing.T) map[string]Limiter {
+	t.Helper()
+	sqliteLimiter, err := NewSQLiteLimiter(SQLiteConfig{Path: filepath.Join(t.TempDir(), "ratelimit.db")}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewSQLiteLimiter: %v", err)
+	}
+
+	mr := miniredis.RunT(t)
+	redisLimiter, err := NewRedisLimiter(RedisConfig{Addr: mr.Addr()}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewRedisLimiter: %v", err)
+	}
+
+	return map[string]Limiter{
+		"memory": NewMemoryLimiter(time.Hour),
+		"sqlite": sqliteLimiter,
+		"redis":  redisLimiter,
+	}
+}
+
+func TestLimiterAllowsFirstRequest(t *testing.T) {
+	for name, limiter := range backendCases(t) {
+		t.Run(name, func(t *testing.T) {
+			allowed, _, err := limiter.Allow(context.Background(), Key{Address: "0xabc", IP: "1.1.1.1"})
+			if err != nil {
+				t.Fatalf("Allow: %v", err)
+			}
+			if !allowed {
+				t.Fatal("first request for a fresh key should be allowed")
+			}
+		})
+	}
+}
+
+func TestLimiterDeniesSameAddressAndIPWithinWindow(t *testing.T) {
+	for name, limiter := range backendCases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			key := Key{Address: "0xabc", IP: "1.1.1.1"}
+			if allowed, _, err := limiter.Allow(ctx, key); err != nil || !allowed {
+				t.Fatalf("first Allow: allowed=%v err=%v", allowed, err)
+			}
+			allowed, retryAfter, err := limiter.Allow(ctx, key)
+			if err != nil {
+				t.Fatalf("second Allow: %v", err)
+			}
+			if allowed {
+				t.Fatal("repeat request for the same key should be denied")
+			}
+			if retryAfter <= 0 {
+				t.Fatal("retryAfter should be positive once denied")
+			}
+		})
+	}
+}
+
+func TestLimiterDeniesNewWalletFromSameIP(t *testing.T) {
+	for name, limiter := range backendCases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if allowed, _, err := limiter.Allow(ctx, Key{Address: "0xabc", IP: "1.1.1.1"}); err != nil || !allowed {
+				t.Fatalf("first Allow: allowed=%v err=%v", allowed, err)
+			}
+			// Same IP, brand new wallet address: should still be blocked by
+			// the IP bucket, not waved through because the address changed.
+			allowed, _, err := limiter.Allow(ctx, Key{Address: "0xdef", IP: "1.1.1.1"})
+			if err != nil {
+				t.Fatalf("Allow: %v", err)
+			}
+			if allowed {
+				t.Fatal("a new wallet from an already-limited IP should be denied")
+			}
+		})
+	}
+}
+
+func TestLimiterDeniesSameWalletFromNewIP(t *testing.T) {
+	for name, limiter := range backendCases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if allowed, _, err := limiter.Allow(ctx, Key{Address: "0xabc", IP: "1.1.1.1"}); err != nil || !allowed {
+				t.Fatalf("first Allow: allowed=%v err=%v", allowed, err)
+			}
+			// Same wallet, new IP: should still be blocked by the address bucket.
+			allowed, _, err := limiter.Allow(ctx, Key{Address: "0xabc", IP: "2.2.2.2"})
+			if err != nil {
+				t.Fatalf("Allow: %v", err)
+			}
+			if allowed {
+				t.Fatal("the same wallet from a new IP should be denied")
+			}
+		})
+	}
+}
+
+func TestLimiterDeniedByIPDoesNotClaimTheAddressBucket(t *testing.T) {
+	for name, limiter := range backendCases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if allowed, _, err := limiter.Allow(ctx, Key{Address: "0xabc", IP: "1.1.1.1"}); err != nil || !allowed {
+				t.Fatalf("first Allow: allowed=%v err=%v", allowed, err)
+			}
+
+			// New wallet, same already-limited IP: denied by the IP bucket.
+			if allowed, _, err := limiter.Allow(ctx, Key{Address: "0xdef", IP: "1.1.1.1"}); err != nil || allowed {
+				t.Fatalf("Allow denied by IP: allowed=%v err=%v", allowed, err)
+			}
+
+			// That denial must not have claimed 0xdef's own address bucket
+			// for the whole window — from a fresh IP it should go through.
+			allowed, _, err := limiter.Allow(ctx, Key{Address: "0xdef", IP: "2.2.2.2"})
+			if err != nil {
+				t.Fatalf("Allow: %v", err)
+			}
+			if !allowed {
+				t.Fatal("the address bucket must not be claimed by a request denied on the IP bucket")
+			}
+		})
+	}
+}
+
+func TestLimiterAllowsUnrelatedAddressAndIP(t *testing.T) {
+	for name, limiter := range backendCases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if allowed, _, err := limiter.Allow(ctx, Key{Address: "0xabc", IP: "1.1.1.1"}); err != nil || !allowed {
+				t.Fatalf("first Allow: allowed=%v err=%v", allowed, err)
+			}
+			allowed, _, err := limiter.Allow(ctx, Key{Address: "0xdef", IP: "2.2.2.2"})
+			if err != nil {
+				t.Fatalf("Allow: %v", err)
+			}
+			if !allowed {
+				t.Fatal("an unrelated address/IP pair should not be limited")
+			}
+		})
+	}
+}
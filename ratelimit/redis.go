@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisConfig mirrors the `rateLimit.redis:` section of configs.yaml.
+type RedisConfig struct {
+	Addr      string `mapstructure:"addr"`
+	Password  string `mapstructure:"password"`
+	DB        int    `mapstructure:"db"`
+	KeyPrefix string `mapstructure:"keyPrefix"`
+}
+
+// RedisLimiter implements the cooldown using a Redis key per bucket. Allow
+// checks both buckets' remaining TTL before claiming either, so denying on
+// one bucket (e.g. the IP) never leaves the other (e.g. the address)
+// claimed for the full window.
+type RedisLimiter struct {
+	client *redis.Client
+	prefix string
+	window time.Duration
+}
+
+// NewRedisLimiter connects to Redis using cfg and wraps it as a Limiter.
+func NewRedisLimiter(cfg RedisConfig, window time.Duration) (*RedisLimiter, error) {
+	if cfg.Addr == "" {
+		return nil, fmt.Errorf("ratelimit: redis.addr is required")
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+	prefix := cfg.KeyPrefix
+	if prefix == "" {
+		prefix = "mantle-faucet:ratelimit:"
+	}
+	return &RedisLimiter{client: client, prefix: prefix, window: window}, nil
+}
+
+func (r *RedisLimiter) Allow(ctx context.Context, key Key) (bool, time.Duration, error) {
+	addrKey := r.prefix + key.addressKey()
+	ipKey := r.prefix + key.ipKey()
+
+	// Check both buckets' remaining cooldown before claiming either one, so
+	// denying on the second bucket never leaves the first claimed for the
+	// whole window.
+	if retryAfter, limited, err := r.ttl(ctx, addrKey); err != nil {
+		return false, 0, err
+	} else if limited {
+		return false, retryAfter, nil
+	}
+	if retryAfter, limited, err := r.ttl(ctx, ipKey); err != nil {
+		return false, 0, err
+	} else if limited {
+		return false, retryAfter, nil
+	}
+
+	if err := r.touch(ctx, addrKey); err != nil {
+		return false, 0, err
+	}
+	if err := r.touch(ctx, ipKey); err != nil {
+		return false, 0, err
+	}
+	return true, 0, nil
+}
+
+// ttl reports the remaining cooldown on redisKey, if any.
+func (r *RedisLimiter) ttl(ctx context.Context, redisKey string) (time.Duration, bool, error) {
+	ttl, err := r.client.PTTL(ctx, redisKey).Result()
+	if err != nil {
+		return 0, false, fmt.Errorf("ratelimit: redis PTTL failed: %w", err)
+	}
+	if ttl > 0 {
+		return ttl, true, nil
+	}
+	return 0, false, nil
+}
+
+// touch claims redisKey for the cooldown window.
+func (r *RedisLimiter) touch(ctx context.Context, redisKey string) error {
+	if err := r.client.Set(ctx, redisKey, time.Now().Unix(), r.window).Err(); err != nil {
+		return fmt.Errorf("ratelimit: redis SET failed: %w", err)
+	}
+	return nil
+}
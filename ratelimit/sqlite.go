@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteConfig mirrors the `rateLimit.sqlite:` section of configs.yaml.
+type SQLiteConfig struct {
+	Path string `mapstructure:"path"`
+}
+
+// SQLiteLimiter persists the cooldown to a local SQLite file, so a
+// single-replica faucet survives restarts without standing up Redis.
+type SQLiteLimiter struct {
+	db     *sql.DB
+	window time.Duration
+}
+
+// NewSQLiteLimiter opens (and migrates) the SQLite database at cfg.Path.
+func NewSQLiteLimiter(cfg SQLiteConfig, window time.Duration) (*SQLiteLimiter, error) {
+	path := cfg.Path
+	if path == "" {
+		path = "ratelimit.db"
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("ratelimit: failed to open sqlite db: %w", err)
+	}
+	const schema = `CREATE TABLE IF NOT EXISTS withdrawals (
+		bucket_key TEXT PRIMARY KEY,
+		last_withdraw_at INTEGER NOT NULL
+	);`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("ratelimit: failed to migrate sqlite db: %w", err)
+	}
+	return &SQLiteLimiter{db: db, window: window}, nil
+}
+
+func (s *SQLiteLimiter) Allow(ctx context.Context, key Key) (bool, time.Duration, error) {
+	now := time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("ratelimit: sqlite begin tx failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, bucket := range [2]string{key.addressKey(), key.ipKey()} {
+		if retryAfter, limited, err := s.limited(ctx, tx, bucket, now); err != nil {
+			return false, 0, err
+		} else if limited {
+			return false, retryAfter, nil
+		}
+	}
+
+	for _, bucket := range [2]string{key.addressKey(), key.ipKey()} {
+		if err := s.touch(ctx, tx, bucket, now); err != nil {
+			return false, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, 0, fmt.Errorf("ratelimit: sqlite commit failed: %w", err)
+	}
+	return true, 0, nil
+}
+
+// limited reports whether bucket is still inside its cooldown window as of
+// now, and if so how much longer the caller must wait.
+func (s *SQLiteLimiter) limited(ctx context.Context, tx *sql.Tx, bucket string, now time.Time) (time.Duration, bool, error) {
+	var lastUnix int64
+	err := tx.QueryRowContext(ctx, `SELECT last_withdraw_at FROM withdrawals WHERE bucket_key = ?`, bucket).Scan(&lastUnix)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("ratelimit: sqlite lookup failed: %w", err)
+	}
+	last := time.Unix(lastUnix, 0)
+	if elapsed := now.Sub(last); elapsed < s.window {
+		return s.window - elapsed, true, nil
+	}
+	return 0, false, nil
+}
+
+// touch records now as bucket's last withdrawal time.
+func (s *SQLiteLimiter) touch(ctx context.Context, tx *sql.Tx, bucket string, now time.Time) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO withdrawals (bucket_key, last_withdraw_at) VALUES (?, ?)
+		ON CONFLICT(bucket_key) DO UPDATE SET last_withdraw_at = excluded.last_withdraw_at`, bucket, now.Unix())
+	if err != nil {
+		return fmt.Errorf("ratelimit: sqlite upsert failed: %w", err)
+	}
+	return nil
+}
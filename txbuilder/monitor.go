@@ -0,0 +1,173 @@
+package txbuilder
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Status is the last known state of a watched withdrawal transaction,
+// returned by the /mantle/tx/:hash endpoint.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusMined   Status = "mined"
+	StatusBumped  Status = "bumped"
+	StatusFailed  Status = "failed"
+)
+
+// TxInfo is the snapshot TxMonitor keeps for a single withdrawal.
+type TxInfo struct {
+	Hash        common.Hash
+	Status      Status
+	SubmittedAt time.Time
+	Replacement *common.Hash // set once a stalled tx has been bumped and resent
+}
+
+// TxMonitor watches submitted withdrawal transactions in the background. If
+// one is not mined within cfg.StallBlocks blocks, it bumps the tip by
+// cfg.BumpPercent and resubmits with the same nonce.
+type TxMonitor struct {
+	client *ethclient.Client
+	cfg    Config
+
+	mu      sync.RWMutex
+	watched map[common.Hash]*TxInfo
+}
+
+// NewTxMonitor builds a TxMonitor polling client. Call Watch for every
+// signed transaction that should be tracked.
+func NewTxMonitor(client *ethclient.Client, cfg Config) *TxMonitor {
+	return &TxMonitor{
+		client:  client,
+		cfg:     cfg.withDefaults(),
+		watched: make(map[common.Hash]*TxInfo),
+	}
+}
+
+// Watch registers tx for monitoring and starts a goroutine that polls until
+// it is mined, bumping and resubmitting with privateKey if it stalls. The
+// client passed to NewTxMonitor is assumed to be owned by the caller (e.g. a
+// network.Pool); TxMonitor never closes it.
+func (m *TxMonitor) Watch(ctx context.Context, tx *types.Transaction, privateKey *ecdsa.PrivateKey) {
+	m.mu.Lock()
+	m.watched[tx.Hash()] = &TxInfo{Hash: tx.Hash(), Status: StatusPending, SubmittedAt: time.Now()}
+	m.mu.Unlock()
+
+	go m.watch(ctx, tx, privateKey)
+}
+
+// Status returns the last known state for hash, or (nil, false) if it is not
+// being tracked.
+func (m *TxMonitor) Status(hash common.Hash) (*TxInfo, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	info, ok := m.watched[hash]
+	if !ok {
+		return nil, false
+	}
+	cp := *info
+	return &cp, true
+}
+
+func (m *TxMonitor) watch(ctx context.Context, tx *types.Transaction, privateKey *ecdsa.PrivateKey) {
+	ticker := time.NewTicker(3 * time.Second)
+	defer ticker.Stop()
+
+	submittedBlock, err := m.client.BlockNumber(ctx)
+	if err != nil {
+		return
+	}
+	current := tx
+	currentHash := tx.Hash()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, isPending, err := m.client.TransactionByHash(ctx, currentHash)
+		if err == nil && !isPending {
+			m.setStatus(currentHash, StatusMined)
+			return
+		}
+
+		latestBlock, err := m.client.BlockNumber(ctx)
+		if err != nil {
+			continue
+		}
+		if latestBlock-submittedBlock < m.cfg.StallBlocks {
+			continue
+		}
+
+		bumped, err := m.bump(ctx, current, privateKey)
+		if err != nil {
+			m.setStatus(currentHash, StatusFailed)
+			return
+		}
+
+		m.mu.Lock()
+		if info, ok := m.watched[currentHash]; ok {
+			replacement := bumped.Hash()
+			info.Status = StatusBumped
+			info.Replacement = &replacement
+		}
+		m.watched[bumped.Hash()] = &TxInfo{Hash: bumped.Hash(), Status: StatusPending, SubmittedAt: time.Now()}
+		m.mu.Unlock()
+
+		current = bumped
+		currentHash = bumped.Hash()
+		submittedBlock = latestBlock
+	}
+}
+
+// bump resends current with its tip and fee cap increased by cfg.BumpPercent,
+// keeping the same nonce so it replaces the stalled transaction.
+func (m *TxMonitor) bump(ctx context.Context, current *types.Transaction, privateKey *ecdsa.PrivateKey) (*types.Transaction, error) {
+	newTip := increasePercent(current.GasTipCap(), m.cfg.BumpPercent)
+	newFeeCap := increasePercent(current.GasFeeCap(), m.cfg.BumpPercent)
+
+	replacement := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   current.ChainId(),
+		Nonce:     current.Nonce(),
+		GasFeeCap: newFeeCap,
+		GasTipCap: newTip,
+		Gas:       current.Gas(),
+		To:        current.To(),
+		Value:     current.Value(),
+		Data:      current.Data(),
+	})
+
+	signed, err := types.SignTx(replacement, types.NewLondonSigner(current.ChainId()), privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.client.SendTransaction(ctx, signed); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+func (m *TxMonitor) setStatus(hash common.Hash, status Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if info, ok := m.watched[hash]; ok {
+		info.Status = status
+	}
+}
+
+// increasePercent scales v up by pct percent, rounding down to a whole wei.
+func increasePercent(v *big.Int, pct float64) *big.Int {
+	scaled := new(big.Float).Mul(new(big.Float).SetInt(v), big.NewFloat(1+pct/100))
+	out, _ := scaled.Int(nil)
+	return out
+}
@@ -0,0 +1,27 @@
+package txbuilder
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestIncreasePercent(t *testing.T) {
+	cases := []struct {
+		name string
+		v    int64
+		pct  float64
+		want int64
+	}{
+		{"default bump percent", 1000, 12.5, 1125},
+		{"zero percent is a no-op", 1000, 0, 1000},
+		{"large bump", 1000, 100, 2000},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := increasePercent(big.NewInt(c.v), c.pct)
+			if got.Cmp(big.NewInt(c.want)) != 0 {
+				t.Fatalf("increasePercent(%d, %v) = %s, want %d", c.v, c.pct, got, c.want)
+			}
+		})
+	}
+}
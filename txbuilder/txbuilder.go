@@ -0,0 +1,145 @@
+// Package txbuilder builds and tracks EIP-1559 withdrawal transactions for
+// the faucet. It replaces the old GasFeeCap = SuggestGasPrice shortcut (a
+// legacy gas price, not a fee cap) with a base-fee-aware calculation, and
+// keeps watching submitted transactions so they get bumped and resubmitted
+// if they stall.
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// GasEstimateRecorder observes how long an EstimateGas call took. Defined
+// here, rather than importing a metrics package, so txbuilder has no
+// dependency on how or whether the caller records metrics.
+type GasEstimateRecorder interface {
+	ObserveEstimateGas(networkName string, d time.Duration)
+}
+
+// Config mirrors the `txbuilder:` section of configs.yaml.
+type Config struct {
+	// BaseFeeMultiplier scales the current base fee before adding the tip cap.
+	// GasFeeCap = baseFee * BaseFeeMultiplier + gasTipCap. Defaults to 2.
+	BaseFeeMultiplier float64 `mapstructure:"baseFeeMultiplier"`
+	// StallBlocks is how many blocks a tx can go unmined before TxMonitor
+	// bumps the tip and resubmits it. Defaults to 3.
+	StallBlocks uint64 `mapstructure:"stallBlocks"`
+	// BumpPercent is the tip increase applied on each resubmission. Defaults
+	// to 12.5, matching the common "replace-by-fee" bump most nodes require.
+	BumpPercent float64 `mapstructure:"bumpPercent"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.BaseFeeMultiplier <= 0 {
+		c.BaseFeeMultiplier = 2
+	}
+	if c.StallBlocks == 0 {
+		c.StallBlocks = 3
+	}
+	if c.BumpPercent <= 0 {
+		c.BumpPercent = 12.5
+	}
+	return c
+}
+
+// Builder builds DynamicFeeTx withdrawal transactions against a single
+// *ethclient.Client, using the current base fee instead of a legacy gas
+// price so EIP-1559 blocks don't over- or under-price the transaction.
+type Builder struct {
+	client *ethclient.Client
+	cfg    Config
+
+	// networkName and recorder are optional; when recorder is nil no metric
+	// is recorded.
+	networkName string
+	recorder    GasEstimateRecorder
+}
+
+// NewBuilder wraps client with cfg (zero-value cfg applies sane defaults).
+// networkName and recorder are optional and only used to label/emit the
+// EstimateGas latency metric; pass "" and nil to skip it.
+func NewBuilder(client *ethclient.Client, cfg Config, networkName string, recorder GasEstimateRecorder) *Builder {
+	return &Builder{client: client, cfg: cfg.withDefaults(), networkName: networkName, recorder: recorder}
+}
+
+// Build fetches the current base fee, tip cap and pending nonce, estimates
+// gas, and returns a ready-to-sign DynamicFeeTx sending value (with data, if
+// any) from `from` to `to`. Concurrent callers racing on the same `from` can
+// still read the same pending nonce; callers that serialize sends themselves
+// (see the dispenser package) should use BuildWithNonce instead.
+func (b *Builder) Build(ctx context.Context, from, to common.Address, value *big.Int, data []byte) (*types.DynamicFeeTx, error) {
+	nonce, err := b.client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("txbuilder: failed to fetch pending nonce: %w", err)
+	}
+	return b.BuildWithNonce(ctx, from, to, value, data, nonce)
+}
+
+// BuildWithNonce is Build, but the caller supplies the nonce instead of it
+// being fetched from PendingNonceAt. This is how the dispenser's
+// nonce-serialized sender avoids two in-flight requests reading and reusing
+// the same pending nonce.
+func (b *Builder) BuildWithNonce(ctx context.Context, from, to common.Address, value *big.Int, data []byte, nonce uint64) (*types.DynamicFeeTx, error) {
+	header, err := b.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("txbuilder: failed to fetch latest header: %w", err)
+	}
+	if header.BaseFee == nil {
+		return nil, fmt.Errorf("txbuilder: chain does not report a base fee (pre-London?)")
+	}
+
+	gasTipCap, err := b.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("txbuilder: failed to suggest gas tip cap: %w", err)
+	}
+
+	gasFeeCap := feeCap(header.BaseFee, b.cfg.BaseFeeMultiplier, gasTipCap)
+
+	chainID, err := b.client.NetworkID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("txbuilder: failed to fetch chain id: %w", err)
+	}
+
+	msg := ethereum.CallMsg{
+		From:      from,
+		To:        &to,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Value:     value,
+		Data:      data,
+	}
+	start := time.Now()
+	gasLimit, err := b.client.EstimateGas(ctx, msg)
+	if b.recorder != nil {
+		b.recorder.ObserveEstimateGas(b.networkName, time.Since(start))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("txbuilder: failed to estimate gas: %w", err)
+	}
+
+	return &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Gas:       gasLimit,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	}, nil
+}
+
+// feeCap computes GasFeeCap = baseFee*multiplier + gasTipCap.
+func feeCap(baseFee *big.Int, multiplier float64, gasTipCap *big.Int) *big.Int {
+	scaled := new(big.Float).Mul(new(big.Float).SetInt(baseFee), big.NewFloat(multiplier))
+	scaledInt, _ := scaled.Int(nil)
+	return new(big.Int).Add(scaledInt, gasTipCap)
+}
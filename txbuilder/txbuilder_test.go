@@ -0,0 +1,28 @@
+package txbuilder
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFeeCap(t *testing.T) {
+	cases := []struct {
+		name       string
+		baseFee    int64
+		multiplier float64
+		gasTipCap  int64
+		want       int64
+	}{
+		{"default 2x multiplier", 100, 2, 10, 210},
+		{"fractional multiplier", 100, 1.5, 10, 160},
+		{"zero tip cap", 100, 2, 0, 200},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := feeCap(big.NewInt(c.baseFee), c.multiplier, big.NewInt(c.gasTipCap))
+			if got.Cmp(big.NewInt(c.want)) != 0 {
+				t.Fatalf("feeCap(%d, %v, %d) = %s, want %d", c.baseFee, c.multiplier, c.gasTipCap, got, c.want)
+			}
+		})
+	}
+}